@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/stayanti/gcp-sa-lookup/output"
+	"github.com/stayanti/gcp-sa-lookup/store"
+)
+
+var analyzeFormat string
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Search the store built by load",
+}
+
+var analyzeProjectCmd = &cobra.Command{
+	Use:   "project <project-id>",
+	Short: "Search by project ID",
+	Args:  cobra.ExactArgs(1),
+	RunE: withStore(func(st store.Store, args []string) error {
+		matches, err := st.SearchByProjectID(args[0])
+		if err != nil {
+			return fmt.Errorf("search failed: %w", err)
+		}
+		return writeResults(matches)
+	}),
+}
+
+var analyzeEmailCmd = &cobra.Command{
+	Use:   "email <substring>",
+	Short: "Search by a partial email match",
+	Args:  cobra.ExactArgs(1),
+	RunE: withStore(func(st store.Store, args []string) error {
+		matches, err := st.SearchByEmail(args[0])
+		if err != nil {
+			return fmt.Errorf("search failed: %w", err)
+		}
+		return writeResults(matches)
+	}),
+}
+
+var analyzeSubjectCmd = &cobra.Command{
+	Use:   "subject <subject-id>",
+	Short: "Search by subject (unique) ID",
+	Args:  cobra.ExactArgs(1),
+	RunE: withStore(func(st store.Store, args []string) error {
+		acc, found, err := st.SearchBySubjectID(args[0])
+		if err != nil {
+			return fmt.Errorf("search failed: %w", err)
+		}
+		if !found {
+			return writeResults(nil)
+		}
+		return writeResults([]store.Account{acc})
+	}),
+}
+
+var analyzeBulkFile string
+
+var analyzeBulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Resolve many subject IDs at once",
+	RunE: withStore(func(st store.Store, args []string) error {
+		ids, err := bulkSubjectIDs(args)
+		if err != nil {
+			return err
+		}
+		found, missing, err := st.BulkLookup(ids)
+		if err != nil {
+			return fmt.Errorf("bulk lookup failed: %w", err)
+		}
+		format, err := output.ParseFormat(analyzeFormat)
+		if err != nil {
+			return err
+		}
+		return output.WriteBulkResult(color.Output, format, found, missing)
+	}),
+}
+
+var analyzeHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List scans recorded by load",
+	RunE: withStore(func(st store.Store, args []string) error {
+		summaries, err := st.ListScans()
+		if err != nil {
+			return fmt.Errorf("listing scan history failed: %w", err)
+		}
+		format, err := output.ParseFormat(analyzeFormat)
+		if err != nil {
+			return err
+		}
+		return output.WriteScanSummaries(color.Output, format, summaries)
+	}),
+}
+
+var analyzeDiffCmd = &cobra.Command{
+	Use:   "diff <from-scan-id> <to-scan-id>",
+	Short: "Show accounts that appeared, disappeared, or changed status between two scans",
+	Args:  cobra.ExactArgs(2),
+	RunE: withStore(func(st store.Store, args []string) error {
+		var fromID, toID int64
+		if _, err := fmt.Sscanf(args[0], "%d", &fromID); err != nil {
+			return fmt.Errorf("invalid from-scan-id %q: %w", args[0], err)
+		}
+		if _, err := fmt.Sscanf(args[1], "%d", &toID); err != nil {
+			return fmt.Errorf("invalid to-scan-id %q: %w", args[1], err)
+		}
+
+		from, err := st.ScanAccounts(fromID)
+		if err != nil {
+			return fmt.Errorf("loading scan %d: %w", fromID, err)
+		}
+		to, err := st.ScanAccounts(toID)
+		if err != nil {
+			return fmt.Errorf("loading scan %d: %w", toID, err)
+		}
+
+		format, err := output.ParseFormat(analyzeFormat)
+		if err != nil {
+			return err
+		}
+		return output.WriteDiff(color.Output, format, store.DiffAccounts(from, to))
+	}),
+}
+
+func init() {
+	analyzeCmd.PersistentFlags().StringVar(&analyzeFormat, "format", "table", "Output format: table, json, csv, ndjson, or tsv")
+	analyzeBulkCmd.Flags().StringVar(&analyzeBulkFile, "file", "", "Path to a file of newline-separated subject IDs (reads stdin if omitted and no IDs are given as arguments)")
+
+	analyzeCmd.AddCommand(analyzeProjectCmd, analyzeEmailCmd, analyzeSubjectCmd, analyzeBulkCmd, analyzeHistoryCmd, analyzeDiffCmd)
+	rootCmd.AddCommand(analyzeCmd)
+}
+
+// withStore wraps a RunE body that needs an open Store, opening it before
+// the call and closing it afterward.
+func withStore(fn func(st store.Store, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		st, _, err := openStore()
+		if err != nil {
+			return fmt.Errorf("opening store: %w", err)
+		}
+		defer st.Close()
+		return fn(st, args)
+	}
+}
+
+// writeResults renders matches in --format, printing a "nothing found"
+// message instead for the human-readable table format.
+func writeResults(matches []store.Account) error {
+	format, err := output.ParseFormat(analyzeFormat)
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 && format == output.Table {
+		fmt.Println("No matching accounts found")
+		return nil
+	}
+	return output.WriteAccounts(color.Output, format, matches)
+}
+
+// bulkSubjectIDs resolves the subject IDs to look up from, in order of
+// precedence: positional args, --file, then stdin.
+func bulkSubjectIDs(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+
+	var r *os.File
+	if analyzeBulkFile != "" {
+		f, err := os.Open(analyzeBulkFile)
+		if err != nil {
+			return nil, fmt.Errorf("opening --file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	} else {
+		r = os.Stdin
+	}
+
+	var ids []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		for _, id := range strings.Split(scanner.Text(), ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, scanner.Err()
+}
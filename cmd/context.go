@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// scanContext returns a context that's canceled on Ctrl-C (SIGINT) so an
+// in-flight scan aborts cleanly instead of leaving orphaned API calls,
+// and additionally canceled after timeout if timeout > 0.
+func scanContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	if timeout <= 0 {
+		return ctx, stop
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
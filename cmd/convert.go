@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/stayanti/gcp-sa-lookup/pkg/scanner"
+	"github.com/stayanti/gcp-sa-lookup/store"
+)
+
+func toStoreAccounts(accounts map[string]scanner.ServiceAccount) map[string]store.Account {
+	out := make(map[string]store.Account, len(accounts))
+	for key, acc := range accounts {
+		out[key] = store.Account{
+			ProjectID: acc.ProjectID,
+			Email:     acc.Email,
+			UniqueId:  acc.UniqueId,
+			Status:    acc.Status,
+		}
+	}
+	return out
+}
+
+func fromStoreAccount(acc store.Account) scanner.ServiceAccount {
+	return scanner.ServiceAccount{
+		ProjectID: acc.ProjectID,
+		Email:     acc.Email,
+		UniqueId:  acc.UniqueId,
+		Status:    acc.Status,
+	}
+}
+
+func fromStoreAccounts(accounts map[string]store.Account) map[string]scanner.ServiceAccount {
+	out := make(map[string]scanner.ServiceAccount, len(accounts))
+	for key, acc := range accounts {
+		out[key] = fromStoreAccount(acc)
+	}
+	return out
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/stayanti/gcp-sa-lookup/output"
+	"github.com/stayanti/gcp-sa-lookup/pkg/scanner"
+	"github.com/stayanti/gcp-sa-lookup/store"
+)
+
+var (
+	interactiveConcurrency       int
+	interactiveNoProgress        bool
+	interactiveHistoryRetention  int
+	interactiveProjectAccessPath string
+	interactiveRateLimit         float64
+	interactiveMaxRetries        int
+	interactiveRetryBackoff      time.Duration
+	interactiveTimeout           time.Duration
+)
+
+// interactiveCmd preserves the original prompt-driven TUI for operators
+// who want to poke around without memorizing the non-interactive
+// subcommands above.
+var interactiveCmd = &cobra.Command{
+	Use:   "interactive",
+	Short: "Run the menu-driven interactive mode (load or analyze)",
+	RunE:  runInteractive,
+}
+
+func init() {
+	interactiveCmd.Flags().IntVarP(&interactiveConcurrency, "concurrency", "c", scanner.DefaultConcurrency, "Set concurrency level")
+	interactiveCmd.Flags().BoolVar(&interactiveNoProgress, "no-progress", false, "Disable the progress bar")
+	interactiveCmd.Flags().IntVar(&interactiveHistoryRetention, "history-retention", 10, "Number of recent scans to keep per store; 0 keeps every scan")
+	interactiveCmd.Flags().StringVar(&interactiveProjectAccessPath, "project-access-report", "project-access.csv", "Path to write the per-project access/error report")
+	interactiveCmd.Flags().Float64Var(&interactiveRateLimit, "rate-limit", 0, "Cap IAM API calls per second across all workers; 0 disables rate limiting")
+	interactiveCmd.Flags().IntVar(&interactiveMaxRetries, "max-retries", scanner.DefaultMaxRetries, "Retries for a project scan that fails with a transient or quota-exceeded error")
+	interactiveCmd.Flags().DurationVar(&interactiveRetryBackoff, "retry-backoff", scanner.DefaultRetryBackoff, "Base delay before the first retry; doubles (plus jitter) on each subsequent retry")
+	interactiveCmd.Flags().DurationVar(&interactiveTimeout, "timeout", 0, "Abort the scan if it hasn't finished after this long; 0 disables the timeout")
+	interactiveCmd.Flags().StringVar(&analyzeFormat, "format", "table", "Analyze-mode output format: table, json, csv, ndjson, or tsv")
+	rootCmd.AddCommand(interactiveCmd)
+}
+
+func runInteractive(cmd *cobra.Command, args []string) error {
+	fmt.Println("GCP Service Account Manager")
+	fmt.Println("============================")
+
+	fmt.Print("Choose mode:\n")
+	fmt.Print("[L] Load service accounts\n")
+	fmt.Print("[A] Analyze existing data\n")
+	fmt.Print("Your choice (L/A): ")
+
+	var modeChoice string
+	fmt.Scanln(&modeChoice)
+
+	if strings.ToUpper(modeChoice) == "A" {
+		return interactiveAnalyze()
+	}
+	return interactiveLoad()
+}
+
+func interactiveLoad() error {
+	result, err := runScan(scanRunOptions{
+		Concurrency:       interactiveConcurrency,
+		NoProgress:        interactiveNoProgress,
+		HistoryRetention:  interactiveHistoryRetention,
+		ProjectAccessPath: interactiveProjectAccessPath,
+		RateLimit:         interactiveRateLimit,
+		MaxRetries:        interactiveMaxRetries,
+		RetryBackoff:      interactiveRetryBackoff,
+		Timeout:           interactiveTimeout,
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return nil
+	}
+
+	fmt.Println("Operation completed successfully")
+	fmt.Printf(" - Service accounts store: %s (%s)\n", result.ResolvedPath, storeBackend)
+	return nil
+}
+
+func interactiveAnalyze() error {
+	st, _, err := openStore()
+	if err != nil {
+		fmt.Printf("Error opening store: %v\n", err)
+		return nil
+	}
+	defer st.Close()
+
+	for {
+		fmt.Println("\nAnalysis Mode - Choose Action")
+		fmt.Println("===============================")
+		fmt.Print("[1] Search by Project ID\n")
+		fmt.Print("[2] Search by Email\n")
+		fmt.Print("[3] Search Single Subject ID\n")
+		fmt.Print("[4] Bulk Subject ID Lookup\n")
+		fmt.Print("[5] Scan History\n")
+		fmt.Print("[6] Diff Two Scans\n")
+		fmt.Print("[7] Exit program\n")
+		fmt.Print("Your choice (1-7): ")
+
+		var choice int
+		_, err := fmt.Scanln(&choice)
+		if err != nil || choice < 1 || choice > 7 {
+			fmt.Println("Invalid input, please try again")
+			continue
+		}
+
+		if choice == 7 {
+			fmt.Println("Exiting...")
+			return nil
+		}
+
+		switch choice {
+		case 1:
+			fmt.Print("Enter Project ID: ")
+			var projectID string
+			fmt.Scanln(&projectID)
+			matches, err := st.SearchByProjectID(projectID)
+			reportSearch(err, matches)
+		case 2:
+			fmt.Print("Enter partial email: ")
+			var email string
+			fmt.Scanln(&email)
+			matches, err := st.SearchByEmail(email)
+			reportSearch(err, matches)
+		case 3:
+			fmt.Print("Enter Subject ID: ")
+			var subjectID string
+			fmt.Scanln(&subjectID)
+			acc, found, err := st.SearchBySubjectID(subjectID)
+			if err != nil {
+				fmt.Printf("Search failed: %v\n", err)
+			} else if !found {
+				reportSearch(nil, nil)
+			} else {
+				reportSearch(nil, []store.Account{acc})
+			}
+		case 4:
+			fmt.Print("Enter comma-separated Subject IDs: ")
+			var input string
+			fmt.Scanln(&input)
+			interactiveBulkLookup(st, input)
+		case 5:
+			interactiveHistory(st)
+		case 6:
+			fmt.Print("Enter the older scan ID: ")
+			var fromID int64
+			fmt.Scanln(&fromID)
+			fmt.Print("Enter the newer scan ID: ")
+			var toID int64
+			fmt.Scanln(&toID)
+			interactiveDiff(st, fromID, toID)
+		}
+
+		fmt.Print("\nPress Enter to continue...")
+		fmt.Scanln() // Wait for user confirmation
+	}
+}
+
+func reportSearch(err error, matches []store.Account) {
+	if err != nil {
+		fmt.Printf("Search failed: %v\n", err)
+		return
+	}
+	if writeErr := writeResults(matches); writeErr != nil {
+		fmt.Printf("Error writing output: %v\n", writeErr)
+	}
+}
+
+func interactiveBulkLookup(st store.Store, input string) {
+	var subjectIDs []string
+	for _, rawID := range strings.Split(input, ",") {
+		if id := strings.TrimSpace(rawID); id != "" {
+			subjectIDs = append(subjectIDs, id)
+		}
+	}
+
+	found, missing, err := st.BulkLookup(subjectIDs)
+	if err != nil {
+		fmt.Printf("Bulk lookup failed: %v\n", err)
+		return
+	}
+
+	format, err := output.ParseFormat(analyzeFormat)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := output.WriteBulkResult(color.Output, format, found, missing); err != nil {
+		fmt.Printf("Error writing output: %v\n", err)
+	}
+}
+
+func interactiveHistory(st store.Store) {
+	fmt.Println("\nRecorded scans:")
+
+	summaries, err := st.ListScans()
+	if err != nil {
+		fmt.Printf("Listing scan history failed: %v\n", err)
+		return
+	}
+
+	format, err := output.ParseFormat(analyzeFormat)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if len(summaries) == 0 && format == output.Table {
+		fmt.Println("No scans recorded yet")
+		return
+	}
+	if err := output.WriteScanSummaries(color.Output, format, summaries); err != nil {
+		fmt.Printf("Error writing output: %v\n", err)
+	}
+}
+
+func interactiveDiff(st store.Store, fromID, toID int64) {
+	fmt.Printf("\nDiffing scan %d against scan %d:\n", fromID, toID)
+
+	from, err := st.ScanAccounts(fromID)
+	if err != nil {
+		fmt.Printf("Loading scan %d failed: %v\n", fromID, err)
+		return
+	}
+	to, err := st.ScanAccounts(toID)
+	if err != nil {
+		fmt.Printf("Loading scan %d failed: %v\n", toID, err)
+		return
+	}
+
+	format, err := output.ParseFormat(analyzeFormat)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := output.WriteDiff(color.Output, format, store.DiffAccounts(from, to)); err != nil {
+		fmt.Printf("Error writing output: %v\n", err)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/stayanti/gcp-sa-lookup/pkg/scanner"
+)
+
+var (
+	loadConcurrency       int
+	loadNoProgress        bool
+	loadHistoryRetention  int
+	loadProjectAccessPath string
+	loadRateLimit         float64
+	loadMaxRetries        int
+	loadRetryBackoff      time.Duration
+	loadTimeout           time.Duration
+)
+
+var loadCmd = &cobra.Command{
+	Use:   "load",
+	Short: "Discover service accounts across visible projects and update the store",
+	RunE:  runLoad,
+}
+
+func init() {
+	loadCmd.Flags().IntVarP(&loadConcurrency, "concurrency", "c", scanner.DefaultConcurrency, "Set concurrency level")
+	loadCmd.Flags().BoolVar(&loadNoProgress, "no-progress", false, "Disable the progress bar")
+	loadCmd.Flags().IntVar(&loadHistoryRetention, "history-retention", 10, "Number of recent scans to keep per store; 0 keeps every scan")
+	loadCmd.Flags().StringVar(&loadProjectAccessPath, "project-access-report", "project-access.csv", "Path to write the per-project access/error report")
+	loadCmd.Flags().Float64Var(&loadRateLimit, "rate-limit", 0, "Cap IAM API calls per second across all workers; 0 disables rate limiting")
+	loadCmd.Flags().IntVar(&loadMaxRetries, "max-retries", scanner.DefaultMaxRetries, "Retries for a project scan that fails with a transient or quota-exceeded error")
+	loadCmd.Flags().DurationVar(&loadRetryBackoff, "retry-backoff", scanner.DefaultRetryBackoff, "Base delay before the first retry; doubles (plus jitter) on each subsequent retry")
+	loadCmd.Flags().DurationVar(&loadTimeout, "timeout", 0, "Abort the scan if it hasn't finished after this long; 0 disables the timeout")
+	rootCmd.AddCommand(loadCmd)
+}
+
+func runLoad(cmd *cobra.Command, args []string) error {
+	result, err := runScan(scanRunOptions{
+		Concurrency:       loadConcurrency,
+		NoProgress:        loadNoProgress,
+		HistoryRetention:  loadHistoryRetention,
+		ProjectAccessPath: loadProjectAccessPath,
+		RateLimit:         loadRateLimit,
+		MaxRetries:        loadMaxRetries,
+		RetryBackoff:      loadRetryBackoff,
+		Timeout:           loadTimeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info().Str("store", result.ResolvedPath).Str("backend", storeBackend).Msg("operation completed successfully")
+	return nil
+}
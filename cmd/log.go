@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// configureLogging sets up the global zerolog logger from --log-level and
+// --log-format. It runs in rootCmd's PersistentPreRunE, after flags have
+// been parsed but before any subcommand's RunE.
+func configureLogging() error {
+	level, err := zerolog.ParseLevel(strings.ToLower(logLevel))
+	if err != nil {
+		return fmt.Errorf("unknown --log-level %q: %w", logLevel, err)
+	}
+	zerolog.SetGlobalLevel(level)
+
+	var writer io.Writer
+	switch logFormat {
+	case "text", "":
+		writer = zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339, NoColor: noColor}
+	case "json":
+		writer = os.Stderr
+	default:
+		return fmt.Errorf("unknown --log-format %q (want text or json)", logFormat)
+	}
+
+	log.Logger = zerolog.New(writer).With().Timestamp().Logger()
+	return nil
+}
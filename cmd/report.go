@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"os"
+
+	"github.com/stayanti/gcp-sa-lookup/pkg/scanner"
+)
+
+// writeProjectAccessCSV persists the per-project access/error report that
+// was previously only accumulated in memory and discarded, so operators
+// can see exactly which projects failed a scan and why.
+func writeProjectAccessCSV(path string, accessStatuses []scanner.ProjectAccess) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	defer w.Flush()
+
+	if err := w.Write([]string{"ProjectID", "HasAccess", "Category"}); err != nil {
+		return err
+	}
+	for _, access := range accessStatuses {
+		if err := w.Write([]string{access.ProjectID, access.HasAccess, string(access.Category)}); err != nil {
+			return err
+		}
+	}
+	return w.Error()
+}
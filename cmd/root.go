@@ -0,0 +1,79 @@
+// Package cmd implements the gcp-sa-lookup command tree: non-interactive
+// subcommands for scripting (load, analyze ...) plus an interactive
+// command that preserves the original prompt-driven TUI.
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+
+	"github.com/stayanti/gcp-sa-lookup/store"
+)
+
+var (
+	storeBackend           string
+	storePath              string
+	credentialsFile        string
+	impersonateServiceAcct string
+	organization           string
+	noColor                bool
+	logLevel               string
+	logFormat              string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "gcp-sa-lookup",
+	Short: "Discover and search GCP service accounts across projects",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if noColor || !isatty.IsTerminal(os.Stdout.Fd()) {
+			color.NoColor = true
+		}
+		return configureLogging()
+	},
+}
+
+// Execute runs the command tree, printing any error to stderr and
+// exiting non-zero.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&storeBackend, "store", "csv", "Backend to persist and search service accounts: csv or sqlite")
+	rootCmd.PersistentFlags().StringVar(&storePath, "store-path", "", "Path to the store file (defaults to service-accounts.csv or service-accounts.db depending on --store)")
+	rootCmd.PersistentFlags().StringVar(&credentialsFile, "credentials", "", "Path to a service account JSON key file (defaults to Application Default Credentials)")
+	rootCmd.PersistentFlags().StringVar(&impersonateServiceAcct, "impersonate-service-account", "", "Service account email to impersonate for API calls")
+	rootCmd.PersistentFlags().StringVar(&organization, "organization", "", "Restrict project discovery to this organization ID (e.g. organizations/123456789012); defaults to all projects visible to the caller")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colorized output")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Minimum log level: debug, info, warn, or error")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Log output format: text or json")
+}
+
+// openStore constructs the Store selected by --store, defaulting
+// --store-path to a backend-appropriate filename when unset, and returns
+// the resolved path alongside it for display purposes.
+func openStore() (store.Store, string, error) {
+	path := storePath
+	switch storeBackend {
+	case "sqlite":
+		if path == "" {
+			path = "service-accounts.db"
+		}
+		s, err := store.NewSQLiteStore(path)
+		return s, path, err
+	case "csv", "":
+		if path == "" {
+			path = "service-accounts.csv"
+		}
+		return store.NewCSVStore(path), path, nil
+	default:
+		return nil, "", fmt.Errorf("unknown --store backend %q (want csv or sqlite)", storeBackend)
+	}
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cheggaaa/pb/v3"
+	"github.com/mattn/go-isatty"
+	"github.com/rs/zerolog/log"
+
+	"github.com/stayanti/gcp-sa-lookup/pkg/scanner"
+	"github.com/stayanti/gcp-sa-lookup/store"
+)
+
+// scanRunOptions configures a single load/interactive scan invocation.
+// The two commands differ only in how they surface NoProgress and the
+// error runScan returns, so every other flag is threaded through here.
+type scanRunOptions struct {
+	Concurrency       int
+	NoProgress        bool
+	HistoryRetention  int
+	ProjectAccessPath string
+	RateLimit         float64
+	MaxRetries        int
+	RetryBackoff      time.Duration
+	Timeout           time.Duration
+}
+
+// scanRunResult carries what a caller needs to report after a successful
+// scan.
+type scanRunResult struct {
+	ResolvedPath string
+}
+
+// runScan discovers projects, scans them for service accounts, and
+// persists the results to the configured store. It's shared by the
+// load and interactive commands so the orchestration (list projects,
+// open the store, run the scan, sync, record history, write the access
+// report) lives in one place; callers decide how to report the error.
+func runScan(opts scanRunOptions) (scanRunResult, error) {
+	ctx, cancel := scanContext(opts.Timeout)
+	defer cancel()
+
+	sc := scanner.New(scanner.Options{
+		Concurrency:               opts.Concurrency,
+		CredentialsFile:           credentialsFile,
+		ImpersonateServiceAccount: impersonateServiceAcct,
+		Organization:              organization,
+		RateLimit:                 opts.RateLimit,
+		MaxRetries:                opts.MaxRetries,
+		RetryBackoff:              opts.RetryBackoff,
+	})
+
+	projects, err := sc.ListProjects(ctx)
+	if err != nil {
+		return scanRunResult{}, fmt.Errorf("listing projects: %w", err)
+	}
+
+	st, resolvedPath, err := openStore()
+	if err != nil {
+		return scanRunResult{}, fmt.Errorf("opening store: %w", err)
+	}
+	defer st.Close()
+
+	storedAccounts, err := st.All()
+	if err != nil {
+		return scanRunResult{}, fmt.Errorf("reading existing accounts: %w", err)
+	}
+	existingAccounts := fromStoreAccounts(storedAccounts)
+
+	log.Info().Int("projects", len(projects)).Int("concurrency", opts.Concurrency).Msg("starting scan")
+	showProgress := !opts.NoProgress && isatty.IsTerminal(os.Stdout.Fd())
+	var bar *pb.ProgressBar
+	if showProgress {
+		bar = pb.Full.Start(len(projects))
+		bar.Set(pb.Bytes, false)
+		bar.SetTemplateString(`{{ "Progress:" }} {{counters . }} {{bar . }} {{percent . }} {{etime . }} ETA {{rtime . "%s"}} {{speed . "%s p/s"}}`)
+	}
+
+	var accessStatuses []scanner.ProjectAccess
+	scanStart := time.Now()
+	sc.Scan(ctx, projects, existingAccounts, func(access scanner.ProjectAccess) {
+		accessStatuses = append(accessStatuses, access)
+		if access.HasAccess == "no" {
+			log.Warn().Str("project", access.ProjectID).Str("category", string(access.Category)).Msg("project scan failed")
+		}
+		if bar != nil {
+			bar.Increment()
+		}
+	})
+	if bar != nil {
+		bar.Finish()
+	}
+	duration := time.Since(scanStart)
+	log.Info().Dur("duration", duration).Msg("scan completed")
+
+	storeAccounts := toStoreAccounts(existingAccounts)
+	if err := st.Sync(storeAccounts); err != nil {
+		return scanRunResult{}, fmt.Errorf("writing store: %w", err)
+	}
+
+	scanResult := store.ScanResult{
+		CompletedAt: time.Now(),
+		Duration:    duration,
+		Accounts:    storeAccounts,
+	}
+	if err := st.RecordScan(scanResult, opts.HistoryRetention); err != nil {
+		return scanRunResult{}, fmt.Errorf("recording scan history: %w", err)
+	}
+
+	if err := writeProjectAccessCSV(opts.ProjectAccessPath, accessStatuses); err != nil {
+		return scanRunResult{}, fmt.Errorf("writing project access report: %w", err)
+	}
+
+	summary := summarizeAccess(accessStatuses)
+	event := log.Info()
+	for category, count := range summary {
+		event = event.Int(category, count)
+	}
+	event.Str("report", opts.ProjectAccessPath).Msg("scan summary")
+
+	return scanRunResult{ResolvedPath: resolvedPath}, nil
+}
+
+// summarizeAccess counts accessStatuses per error category, using
+// "ACCESSIBLE" for projects the scan could reach.
+func summarizeAccess(accessStatuses []scanner.ProjectAccess) map[string]int {
+	counts := make(map[string]int)
+	for _, access := range accessStatuses {
+		category := "ACCESSIBLE"
+		if access.HasAccess == "no" {
+			category = string(access.Category)
+			if category == "" {
+				category = string(scanner.CategoryUnknown)
+			}
+		}
+		counts[category]++
+	}
+	return counts
+}
@@ -0,0 +1,219 @@
+// Package output renders analyze-mode search results in the format
+// requested via --format, so the tool's output can be read by a human
+// (table), diffed (csv/tsv), or piped into jq/CI tooling (json/ndjson).
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/stayanti/gcp-sa-lookup/store"
+)
+
+var (
+	activeColor  = color.New(color.FgGreen).SprintFunc()
+	deletedColor = color.New(color.FgRed).SprintFunc()
+	otherColor   = color.New(color.FgYellow).SprintFunc()
+)
+
+// statusText colors a status for the table format: active in green,
+// deleted in red, anything else (e.g. error placeholders) in yellow.
+func statusText(status string) string {
+	switch status {
+	case "active":
+		return activeColor(status)
+	case "deleted":
+		return deletedColor(status)
+	default:
+		return otherColor(status)
+	}
+}
+
+// Format is one of the values accepted by --format.
+type Format string
+
+const (
+	Table  Format = "table"
+	JSON   Format = "json"
+	CSV    Format = "csv"
+	NDJSON Format = "ndjson"
+	TSV    Format = "tsv"
+)
+
+// ParseFormat validates a --format flag value.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case Table, JSON, CSV, NDJSON, TSV:
+		return Format(s), nil
+	default:
+		return "", fmt.Errorf("unknown --format %q (want table, json, csv, ndjson, or tsv)", s)
+	}
+}
+
+// WriteAccounts renders accounts to w in the given format.
+func WriteAccounts(w io.Writer, format Format, accounts []store.Account) error {
+	switch format {
+	case Table, "":
+		return writeTable(w, accounts)
+	case CSV:
+		return writeDelimited(w, accounts, ',')
+	case TSV:
+		return writeDelimited(w, accounts, '\t')
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(accounts)
+	case NDJSON:
+		enc := json.NewEncoder(w)
+		for _, acc := range accounts {
+			if err := enc.Encode(acc); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// BulkResult is the structured document emitted for bulk subject ID
+// lookups in JSON/NDJSON format, so callers can pipe it into jq.
+type BulkResult struct {
+	Found   []store.Account `json:"found"`
+	Missing []string        `json:"missing"`
+}
+
+// WriteBulkResult renders a bulk subject ID lookup to w in the given
+// format. For table/csv/tsv, found accounts and missing IDs are rendered
+// as two sections; for json/ndjson, a single BulkResult document with
+// "found" and "missing" arrays is emitted.
+func WriteBulkResult(w io.Writer, format Format, found []store.Account, missing []string) error {
+	switch format {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(BulkResult{Found: found, Missing: missing})
+	case NDJSON:
+		enc := json.NewEncoder(w)
+		if err := enc.Encode(BulkResult{Found: found, Missing: missing}); err != nil {
+			return err
+		}
+		return nil
+	default:
+		if err := WriteAccounts(w, format, found); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\nMissing %d subject IDs:\n", len(missing))
+		for _, id := range missing {
+			fmt.Fprintln(w, " -", id)
+		}
+		return nil
+	}
+}
+
+// WriteScanSummaries renders the "history" listing of recorded scans to w
+// in the given format.
+func WriteScanSummaries(w io.Writer, format Format, summaries []store.ScanSummary) error {
+	switch format {
+	case Table, "":
+		tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+		fmt.Fprintln(tw, "SCAN ID\tCOMPLETED AT\tDURATION\tACCOUNTS")
+		for _, s := range summaries {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%d\n", s.ScanID, s.CompletedAt.Format("2006-01-02 15:04:05"), s.Duration, s.AccountCount)
+		}
+		return tw.Flush()
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summaries)
+	case NDJSON:
+		enc := json.NewEncoder(w)
+		for _, s := range summaries {
+			if err := enc.Encode(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	case CSV, TSV:
+		delimiter := ','
+		if format == TSV {
+			delimiter = '\t'
+		}
+		cw := csv.NewWriter(w)
+		cw.Comma = delimiter
+		defer cw.Flush()
+		if err := cw.Write([]string{"ScanID", "CompletedAt", "Duration", "Accounts"}); err != nil {
+			return err
+		}
+		for _, s := range summaries {
+			row := []string{fmt.Sprintf("%d", s.ScanID), s.CompletedAt.Format(time.RFC3339), s.Duration.String(), fmt.Sprintf("%d", s.AccountCount)}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// WriteDiff renders the accounts that appeared, disappeared, or changed
+// status between two scans. JSON/NDJSON emit the store.AccountDiff
+// directly; the remaining formats render each section as its own table.
+func WriteDiff(w io.Writer, format Format, diff store.AccountDiff) error {
+	switch format {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diff)
+	case NDJSON:
+		return json.NewEncoder(w).Encode(diff)
+	default:
+		fmt.Fprintf(w, "Appeared (%d):\n", len(diff.Appeared))
+		if err := WriteAccounts(w, format, diff.Appeared); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\nDisappeared (%d):\n", len(diff.Disappeared))
+		if err := WriteAccounts(w, format, diff.Disappeared); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\nChanged (%d):\n", len(diff.Changed))
+		tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+		fmt.Fprintln(tw, "PROJECT ID\tEMAIL\tSUBJECT ID\tBEFORE\tAFTER")
+		for _, c := range diff.Changed {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", c.Before.ProjectID, c.Before.Email, c.Before.UniqueId, statusText(c.Before.Status), statusText(c.After.Status))
+		}
+		return tw.Flush()
+	}
+}
+
+func writeTable(w io.Writer, accounts []store.Account) error {
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROJECT ID\tEMAIL\tSUBJECT ID\tSTATUS")
+	for _, acc := range accounts {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", acc.ProjectID, acc.Email, acc.UniqueId, statusText(acc.Status))
+	}
+	return tw.Flush()
+}
+
+func writeDelimited(w io.Writer, accounts []store.Account, delimiter rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = delimiter
+	defer cw.Flush()
+
+	if err := cw.Write([]string{"ProjectID", "Email", "SubjectID", "Status"}); err != nil {
+		return err
+	}
+	for _, acc := range accounts {
+		if err := cw.Write([]string{acc.ProjectID, acc.Email, acc.UniqueId, acc.Status}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/stayanti/gcp-sa-lookup/store"
+)
+
+func TestMain(m *testing.M) {
+	color.NoColor = true
+	m.Run()
+}
+
+func sampleAccounts() []store.Account {
+	return []store.Account{
+		{ProjectID: "proj-a", Email: "deploy-bot@proj-a.iam.gserviceaccount.com", UniqueId: "111", Status: "active"},
+		{ProjectID: "proj-b", Email: "ci@proj-b.iam.gserviceaccount.com", UniqueId: "222", Status: "deleted"},
+	}
+}
+
+// TestWriteAccounts captures the golden output of WriteAccounts for every
+// supported --format value, so a format-rendering regression fails a test
+// instead of only showing up as a diff in someone's terminal.
+func TestWriteAccounts(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{
+			format: Table,
+			want: "PROJECT ID  EMAIL                                      SUBJECT ID  STATUS\n" +
+				"proj-a      deploy-bot@proj-a.iam.gserviceaccount.com  111         active\n" +
+				"proj-b      ci@proj-b.iam.gserviceaccount.com          222         deleted\n",
+		},
+		{
+			format: CSV,
+			want: "ProjectID,Email,SubjectID,Status\n" +
+				"proj-a,deploy-bot@proj-a.iam.gserviceaccount.com,111,active\n" +
+				"proj-b,ci@proj-b.iam.gserviceaccount.com,222,deleted\n",
+		},
+		{
+			format: TSV,
+			want: "ProjectID\tEmail\tSubjectID\tStatus\n" +
+				"proj-a\tdeploy-bot@proj-a.iam.gserviceaccount.com\t111\tactive\n" +
+				"proj-b\tci@proj-b.iam.gserviceaccount.com\t222\tdeleted\n",
+		},
+		{
+			format: JSON,
+			want: `[
+  {
+    "projectId": "proj-a",
+    "email": "deploy-bot@proj-a.iam.gserviceaccount.com",
+    "uniqueId": "111",
+    "status": "active"
+  },
+  {
+    "projectId": "proj-b",
+    "email": "ci@proj-b.iam.gserviceaccount.com",
+    "uniqueId": "222",
+    "status": "deleted"
+  }
+]
+`,
+		},
+		{
+			format: NDJSON,
+			want: `{"projectId":"proj-a","email":"deploy-bot@proj-a.iam.gserviceaccount.com","uniqueId":"111","status":"active"}
+{"projectId":"proj-b","email":"ci@proj-b.iam.gserviceaccount.com","uniqueId":"222","status":"deleted"}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteAccounts(&buf, tt.format, sampleAccounts()); err != nil {
+				t.Fatalf("WriteAccounts: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteAccounts(%s) output mismatch\ngot:\n%s\nwant:\n%s", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWriteBulkResult_JSON pins the structured found/missing document so
+// downstream jq/CI consumers don't silently see its shape change.
+func TestWriteBulkResult_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	found := []store.Account{
+		{ProjectID: "proj-a", Email: "deploy-bot@proj-a.iam.gserviceaccount.com", UniqueId: "111", Status: "active"},
+	}
+	missing := []string{"999"}
+
+	if err := WriteBulkResult(&buf, JSON, found, missing); err != nil {
+		t.Fatalf("WriteBulkResult: %v", err)
+	}
+
+	want := `{
+  "found": [
+    {
+      "projectId": "proj-a",
+      "email": "deploy-bot@proj-a.iam.gserviceaccount.com",
+      "uniqueId": "111",
+      "status": "active"
+    }
+  ],
+  "missing": [
+    "999"
+  ]
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteBulkResult(JSON) output mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestWriteScanSummaries_Table pins the tabwriter alignment for the
+// analyze-mode "history" listing.
+func TestWriteScanSummaries_Table(t *testing.T) {
+	var buf bytes.Buffer
+	summaries := []store.ScanSummary{
+		{ScanID: 2, CompletedAt: time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC), Duration: 90 * time.Second, AccountCount: 42},
+	}
+
+	if err := WriteScanSummaries(&buf, Table, summaries); err != nil {
+		t.Fatalf("WriteScanSummaries: %v", err)
+	}
+
+	want := "SCAN ID  COMPLETED AT         DURATION  ACCOUNTS\n" +
+		"2        2026-01-02 15:04:05  1m30s     42\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteScanSummaries(Table) output mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+package scanner
+
+import (
+	"errors"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+)
+
+// ErrorCategory buckets a per-project scan failure so operators can see
+// at a glance whether it's worth retrying, requesting access, or
+// ignoring (a disabled project).
+type ErrorCategory string
+
+const (
+	// CategoryNone means the project scanned successfully.
+	CategoryNone             ErrorCategory = ""
+	CategoryPermissionDenied ErrorCategory = "PERMISSION_DENIED"
+	CategoryProjectDisabled  ErrorCategory = "PROJECT_DISABLED"
+	CategoryQuotaExceeded    ErrorCategory = "QUOTA_EXCEEDED"
+	CategoryTransient        ErrorCategory = "TRANSIENT"
+	CategoryUnknown          ErrorCategory = "UNKNOWN"
+)
+
+// classifyError buckets an error returned from ListServiceAccounts into
+// one of the ErrorCategory values above. It prefers the structured HTTP
+// status carried by *googleapi.Error and falls back to matching the
+// error text for wrapped or non-API errors (e.g. context deadlines).
+func classifyError(err error) ErrorCategory {
+	if err == nil {
+		return CategoryNone
+	}
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case 403:
+			if strings.Contains(strings.ToLower(apiErr.Message), "disabled") {
+				return CategoryProjectDisabled
+			}
+			return CategoryPermissionDenied
+		case 429:
+			return CategoryQuotaExceeded
+		case 500, 502, 503, 504:
+			return CategoryTransient
+		default:
+			return CategoryUnknown
+		}
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "permission"):
+		return CategoryPermissionDenied
+	case strings.Contains(msg, "disabled"):
+		return CategoryProjectDisabled
+	case strings.Contains(msg, "quota"), strings.Contains(msg, "rate limit"):
+		return CategoryQuotaExceeded
+	case strings.Contains(msg, "deadline exceeded"), strings.Contains(msg, "timeout"),
+		strings.Contains(msg, "unavailable"), strings.Contains(msg, "context canceled"):
+		return CategoryTransient
+	default:
+		return CategoryUnknown
+	}
+}
+
+// retryable reports whether a scan failure is worth retrying with
+// backoff: transient infrastructure hiccups and quota exhaustion, but
+// never a permanent permission or disabled-project error.
+func retryable(category ErrorCategory) bool {
+	return category == CategoryTransient || category == CategoryQuotaExceeded
+}
@@ -0,0 +1,342 @@
+// Package scanner discovers GCP projects and the service accounts within
+// them, concurrently and with bounded parallelism. It has no knowledge of
+// how results are displayed or persisted, so it can be driven by both the
+// interactive TUI and the non-interactive CLI subcommands.
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
+	"cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
+	"golang.org/x/time/rate"
+	"google.golang.org/api/iam/v1"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+const (
+	// DefaultConcurrency is used when Options.Concurrency is left at zero.
+	DefaultConcurrency = 20
+
+	// DefaultMaxRetries is the suggested --max-retries default; callers
+	// that want no retries pass Options.MaxRetries = 0 explicitly.
+	DefaultMaxRetries = 3
+
+	// DefaultRetryBackoff is used when Options.RetryBackoff is left at
+	// zero.
+	DefaultRetryBackoff = 500 * time.Millisecond
+)
+
+// Project is a GCP project visible to the caller.
+type Project struct {
+	ProjectId string `json:"projectId"`
+	Name      string `json:"name"`
+}
+
+// ServiceAccount is a service account discovered within a project.
+type ServiceAccount struct {
+	ProjectID string
+	Email     string `json:"email"`
+	UniqueId  string `json:"uniqueId"`
+	Status    string
+}
+
+// ProjectAccess records whether the caller's credentials could list
+// service accounts in a given project, and if not, why.
+type ProjectAccess struct {
+	ProjectID string
+	HasAccess string // "yes" or "no"
+	Category  ErrorCategory
+}
+
+type projectResult struct {
+	Project         Project
+	ServiceAccounts []ServiceAccount
+	Error           error
+}
+
+// Options configures a Scanner.
+type Options struct {
+	// Concurrency is the number of projects scanned in parallel. Zero
+	// means DefaultConcurrency.
+	Concurrency int
+
+	// CredentialsFile is the path to a service account JSON key file.
+	// Empty means Application Default Credentials.
+	CredentialsFile string
+
+	// ImpersonateServiceAccount is the email of a service account to
+	// impersonate for API calls, if any.
+	ImpersonateServiceAccount string
+
+	// Organization restricts project discovery to this organization ID
+	// (e.g. "organizations/123456789012"). Empty searches every project
+	// visible to the caller.
+	Organization string
+
+	// RateLimit caps IAM API calls per second across all workers, to
+	// stay under GCP quotas. Zero disables rate limiting.
+	RateLimit float64
+
+	// MaxRetries is the number of additional attempts made for a
+	// project whose scan fails with a transient or quota-exceeded
+	// error. Zero disables retries.
+	MaxRetries int
+
+	// RetryBackoff is the base delay before the first retry; each
+	// subsequent retry doubles it, plus jitter. Zero means
+	// DefaultRetryBackoff.
+	RetryBackoff time.Duration
+}
+
+// Scanner discovers projects and service accounts using the GCP Resource
+// Manager and IAM APIs.
+type Scanner struct {
+	opts    Options
+	limiter *rate.Limiter
+
+	iamMu      sync.Mutex
+	iamService *iam.Service
+}
+
+// New returns a Scanner configured by opts.
+func New(opts Options) *Scanner {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = DefaultRetryBackoff
+	}
+
+	s := &Scanner{opts: opts}
+	if opts.RateLimit > 0 {
+		burst := int(opts.RateLimit)
+		if burst < 1 {
+			burst = 1
+		}
+		s.limiter = rate.NewLimiter(rate.Limit(opts.RateLimit), burst)
+	}
+	return s
+}
+
+// clientOptions builds the option.ClientOption set shared by the
+// Resource Manager and IAM clients.
+func (s *Scanner) clientOptions() []option.ClientOption {
+	var opts []option.ClientOption
+	if s.opts.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(s.opts.CredentialsFile))
+	}
+	if s.opts.ImpersonateServiceAccount != "" {
+		opts = append(opts, option.ImpersonateCredentials(s.opts.ImpersonateServiceAccount))
+	}
+	return opts
+}
+
+// ListProjects discovers projects visible to the caller via the Cloud
+// Resource Manager SearchProjects API. If Options.Organization is set,
+// discovery is scoped to that organization instead of searching every
+// project the caller can see.
+func (s *Scanner) ListProjects(ctx context.Context) ([]Project, error) {
+	client, err := resourcemanager.NewProjectsClient(ctx, s.clientOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("creating Resource Manager client: %w", err)
+	}
+	defer client.Close()
+
+	req := &resourcemanagerpb.SearchProjectsRequest{}
+	if s.opts.Organization != "" {
+		req.Query = fmt.Sprintf("parent:%s", s.opts.Organization)
+	}
+
+	var projects []Project
+	it := client.SearchProjects(ctx, req)
+	for {
+		p, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("searching projects: %w", err)
+		}
+		projects = append(projects, Project{
+			ProjectId: p.ProjectId,
+			Name:      p.DisplayName,
+		})
+	}
+
+	return projects, nil
+}
+
+// iamClient returns the Scanner's shared IAM client, creating it on the
+// first call. Reusing one client across workers and retries avoids
+// rebuilding credentials and HTTP transports on every project scanned.
+// A failed creation attempt isn't cached, so a transient error (e.g. a
+// momentary ADC hiccup) doesn't permanently disable IAM access for the
+// rest of the scan.
+func (s *Scanner) iamClient(ctx context.Context) (*iam.Service, error) {
+	s.iamMu.Lock()
+	defer s.iamMu.Unlock()
+	if s.iamService != nil {
+		return s.iamService, nil
+	}
+
+	svc, err := iam.NewService(ctx, s.clientOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	s.iamService = svc
+	return s.iamService, nil
+}
+
+// ListServiceAccounts lists the service accounts in projectID via the IAM
+// API, paginating through results automatically.
+func (s *Scanner) ListServiceAccounts(ctx context.Context, projectID string) ([]ServiceAccount, error) {
+	iamService, err := s.iamClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating IAM client: %w", err)
+	}
+
+	var accounts []ServiceAccount
+	name := fmt.Sprintf("projects/%s", projectID)
+	call := iamService.Projects.ServiceAccounts.List(name)
+	err = call.Pages(ctx, func(page *iam.ListServiceAccountsResponse) error {
+		for _, sa := range page.Accounts {
+			accounts = append(accounts, ServiceAccount{
+				Email:    sa.Email,
+				UniqueId: sa.UniqueId,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing service accounts for project %s: %w", projectID, err)
+	}
+
+	return accounts, nil
+}
+
+// listServiceAccountsWithRetry calls ListServiceAccounts, retrying up to
+// Options.MaxRetries times with exponential backoff and jitter when the
+// failure is classified as transient or quota-exceeded. It waits on the
+// rate limiter, if any, before every attempt (including retries) and
+// gives up immediately if ctx is canceled.
+func (s *Scanner) listServiceAccountsWithRetry(ctx context.Context, projectID string) ([]ServiceAccount, error) {
+	var lastErr error
+	for attempt := 0; attempt <= s.opts.MaxRetries; attempt++ {
+		if s.limiter != nil {
+			if err := s.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		accounts, err := s.ListServiceAccounts(ctx, projectID)
+		if err == nil {
+			return accounts, nil
+		}
+		lastErr = err
+
+		if attempt == s.opts.MaxRetries || !retryable(classifyError(err)) {
+			return nil, lastErr
+		}
+
+		delay := s.opts.RetryBackoff * time.Duration(1<<uint(attempt))
+		delay += time.Duration(rand.Int63n(int64(s.opts.RetryBackoff) + 1)) // jitter
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return nil, lastErr
+}
+
+// Scan lists service accounts in every project using a fixed pool of
+// Options.Concurrency workers reading from a shared job queue (rather
+// than one goroutine per project), merging discovered accounts into
+// existing and reporting each project's access status via onProgress as
+// it completes. onProgress may be nil. Scan returns as soon as ctx is
+// canceled, leaving any unstarted projects unprocessed; it returns the
+// set of project IDs the caller could access.
+//
+// Once a project is confirmed accessible, any entry already in existing
+// for that project that wasn't rediscovered is pruned, since the account
+// no longer exists in GCP. Projects that weren't scanned, or whose scan
+// failed, are left untouched so their accounts aren't dropped on a
+// transient error.
+func (s *Scanner) Scan(ctx context.Context, projects []Project, existing map[string]ServiceAccount, onProgress func(ProjectAccess)) map[string]bool {
+	jobs := make(chan Project)
+	results := make(chan projectResult)
+	accessible := make(map[string]bool)
+	discovered := make(map[string]bool)
+	var mu sync.Mutex
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				accounts, err := s.listServiceAccountsWithRetry(ctx, p.ProjectId)
+				select {
+				case results <- projectResult{Project: p, ServiceAccounts: accounts, Error: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, project := range projects {
+			select {
+			case jobs <- project:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for res := range results {
+		mu.Lock()
+		access := ProjectAccess{ProjectID: res.Project.ProjectId, HasAccess: "yes"}
+		if res.Error != nil {
+			access.HasAccess = "no"
+			access.Category = classifyError(res.Error)
+		} else {
+			accessible[res.Project.ProjectId] = true
+			for _, acc := range res.ServiceAccounts {
+				key := fmt.Sprintf("%s|%s|%s", res.Project.ProjectId, acc.Email, acc.UniqueId)
+				existing[key] = ServiceAccount{
+					ProjectID: res.Project.ProjectId,
+					Email:     acc.Email,
+					UniqueId:  acc.UniqueId,
+					Status:    "active",
+				}
+				discovered[key] = true
+			}
+		}
+		mu.Unlock()
+		if onProgress != nil {
+			onProgress(access)
+		}
+	}
+
+	for key, acc := range existing {
+		if accessible[acc.ProjectID] && !discovered[key] {
+			delete(existing, key)
+		}
+	}
+
+	return accessible
+}
@@ -0,0 +1,242 @@
+package store
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// csvStore is the original flat-file backend. Every search is a linear
+// scan of an in-memory map loaded from disk.
+type csvStore struct {
+	path string
+}
+
+// NewCSVStore returns a Store backed by a CSV file at path, preserving the
+// tool's historical on-disk format.
+func NewCSVStore(path string) Store {
+	return &csvStore{path: path}
+}
+
+func (s *csvStore) Sync(accounts map[string]Account) error {
+	existing, err := s.All()
+	if err != nil {
+		return err
+	}
+
+	for key, acc := range existing {
+		if _, stillPresent := accounts[key]; !stillPresent {
+			acc.Status = "deleted"
+			accounts[key] = acc
+		}
+	}
+
+	file, err := os.Create(s.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	writer.Write([]string{"ProjectID", "Email", "SubjectID", "Status"})
+	for _, acc := range accounts {
+		writer.Write([]string{acc.ProjectID, acc.Email, acc.UniqueId, acc.Status})
+	}
+	return nil
+}
+
+func (s *csvStore) All() (map[string]Account, error) {
+	accounts := make(map[string]Account)
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return accounts, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, _ := reader.ReadAll()
+	for i, row := range records {
+		if i == 0 || len(row) < 4 { // skip header / malformed rows
+			continue
+		}
+		key := fmt.Sprintf("%s|%s|%s", row[0], row[1], row[2])
+		accounts[key] = Account{
+			ProjectID: row[0],
+			Email:     row[1],
+			UniqueId:  row[2],
+			Status:    row[3],
+		}
+	}
+	return accounts, nil
+}
+
+func (s *csvStore) SearchByProjectID(projectID string) ([]Account, error) {
+	accounts, err := s.All()
+	if err != nil {
+		return nil, err
+	}
+	var matches []Account
+	for _, acc := range accounts {
+		if acc.ProjectID == projectID {
+			matches = append(matches, acc)
+		}
+	}
+	return matches, nil
+}
+
+func (s *csvStore) SearchByEmail(substr string) ([]Account, error) {
+	accounts, err := s.All()
+	if err != nil {
+		return nil, err
+	}
+	searchTerm := strings.ToLower(substr)
+	var matches []Account
+	for _, acc := range accounts {
+		if strings.Contains(strings.ToLower(acc.Email), searchTerm) {
+			matches = append(matches, acc)
+		}
+	}
+	return matches, nil
+}
+
+func (s *csvStore) SearchBySubjectID(subjectID string) (Account, bool, error) {
+	accounts, err := s.All()
+	if err != nil {
+		return Account{}, false, err
+	}
+	for _, acc := range accounts {
+		if acc.UniqueId == subjectID {
+			return acc, true, nil
+		}
+	}
+	return Account{}, false, nil
+}
+
+func (s *csvStore) BulkLookup(ids []string) ([]Account, []string, error) {
+	accounts, err := s.All()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var found []Account
+	var missing []string
+	for _, id := range ids {
+		matched := false
+		for _, acc := range accounts {
+			if acc.UniqueId == id {
+				found = append(found, acc)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			missing = append(missing, id)
+		}
+	}
+	return found, missing, nil
+}
+
+// historyDir is the sidecar directory where csvStore keeps one JSON
+// snapshot per scan, since the flat CSV file itself only ever holds the
+// latest state.
+func (s *csvStore) historyDir() string {
+	return s.path + ".history"
+}
+
+// RecordScan writes accounts as a JSON snapshot named after a Unix-nano
+// scan ID, then deletes snapshots beyond the retain most recent ones.
+func (s *csvStore) RecordScan(result ScanResult, retain int) error {
+	dir := s.historyDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating history directory: %w", err)
+	}
+
+	scanID := result.ScanID
+	if scanID == 0 {
+		scanID = result.CompletedAt.UnixNano()
+	}
+	result.ScanID = scanID
+
+	blob, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("encoding scan result: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%d.json", scanID))
+	if err := os.WriteFile(path, blob, 0o644); err != nil {
+		return fmt.Errorf("writing scan snapshot: %w", err)
+	}
+
+	if retain > 0 {
+		summaries, err := s.ListScans()
+		if err != nil {
+			return err
+		}
+		for _, stale := range summaries[min(retain, len(summaries)):] {
+			os.Remove(filepath.Join(dir, fmt.Sprintf("%d.json", stale.ScanID)))
+		}
+	}
+	return nil
+}
+
+func (s *csvStore) ListScans() ([]ScanSummary, error) {
+	entries, err := os.ReadDir(s.historyDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var summaries []ScanSummary
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		result, err := s.readScanFile(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, ScanSummary{
+			ScanID:       result.ScanID,
+			CompletedAt:  result.CompletedAt,
+			Duration:     result.Duration,
+			AccountCount: len(result.Accounts),
+		})
+	}
+
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].ScanID > summaries[j].ScanID })
+	return summaries, nil
+}
+
+func (s *csvStore) ScanAccounts(scanID int64) (map[string]Account, error) {
+	result, err := s.readScanFile(fmt.Sprintf("%d.json", scanID))
+	if err != nil {
+		return nil, fmt.Errorf("no scan with id %d: %w", scanID, err)
+	}
+	return result.Accounts, nil
+}
+
+func (s *csvStore) readScanFile(name string) (ScanResult, error) {
+	blob, err := os.ReadFile(filepath.Join(s.historyDir(), name))
+	if err != nil {
+		return ScanResult{}, err
+	}
+	var result ScanResult
+	if err := json.Unmarshal(blob, &result); err != nil {
+		return ScanResult{}, err
+	}
+	return result, nil
+}
+
+func (s *csvStore) Close() error {
+	return nil
+}
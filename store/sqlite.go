@@ -0,0 +1,363 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // CGO-free sqlite driver
+)
+
+// migrations holds one entry per schema version; migrate applies whichever
+// suffix is newer than the database's current version.
+var migrations = [][]string{
+	// v1: accounts + FTS5 index
+	{
+		`CREATE TABLE IF NOT EXISTS accounts (
+			project_id   TEXT NOT NULL,
+			email        TEXT NOT NULL,
+			unique_id    TEXT NOT NULL,
+			display_name TEXT NOT NULL DEFAULT '',
+			status       TEXT NOT NULL DEFAULT 'active',
+			first_seen   DATETIME NOT NULL,
+			last_seen    DATETIME NOT NULL,
+			PRIMARY KEY (project_id, email, unique_id)
+		)`,
+		`CREATE VIRTUAL TABLE IF NOT EXISTS accounts_fts USING fts5(
+			email, project_id, unique_id, display_name,
+			content='accounts', content_rowid='rowid'
+		)`,
+		`CREATE TRIGGER IF NOT EXISTS accounts_ai AFTER INSERT ON accounts BEGIN
+			INSERT INTO accounts_fts(rowid, email, project_id, unique_id, display_name)
+			VALUES (new.rowid, new.email, new.project_id, new.unique_id, new.display_name);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS accounts_ad AFTER DELETE ON accounts BEGIN
+			INSERT INTO accounts_fts(accounts_fts, rowid, email, project_id, unique_id, display_name)
+			VALUES ('delete', old.rowid, old.email, old.project_id, old.unique_id, old.display_name);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS accounts_au AFTER UPDATE ON accounts BEGIN
+			INSERT INTO accounts_fts(accounts_fts, rowid, email, project_id, unique_id, display_name)
+			VALUES ('delete', old.rowid, old.email, old.project_id, old.unique_id, old.display_name);
+			INSERT INTO accounts_fts(rowid, email, project_id, unique_id, display_name)
+			VALUES (new.rowid, new.email, new.project_id, new.unique_id, new.display_name);
+		END`,
+	},
+	// v2: per-scan snapshots for the history/diff feature
+	{
+		`CREATE TABLE IF NOT EXISTS scans (
+			id           INTEGER PRIMARY KEY AUTOINCREMENT,
+			completed_at DATETIME NOT NULL,
+			duration_ms  INTEGER NOT NULL,
+			result       TEXT NOT NULL
+		)`,
+	},
+}
+
+// schemaVersion is the number of migrations defined above.
+var schemaVersion = len(migrations)
+
+// sqliteStore indexes accounts in a local SQLite database instead of
+// loading the whole inventory into memory for every search, as the CSV
+// backend does.
+type sqliteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if necessary) a SQLite database at path
+// and runs any pending schema migrations.
+func NewSQLiteStore(path string) (Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite database: %w", err)
+	}
+
+	s := &sqliteStore{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrating sqlite database: %w", err)
+	}
+	return s, nil
+}
+
+func (s *sqliteStore) migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL)`); err != nil {
+		return err
+	}
+
+	var current int
+	row := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`)
+	if err := row.Scan(&current); err != nil {
+		return err
+	}
+
+	for version := current + 1; version <= schemaVersion; version++ {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		for _, stmt := range migrations[version-1] {
+			if _, err := tx.Exec(stmt); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("migration v%d: %w", version, err)
+			}
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, version); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sync upserts accounts, marking every previously active row absent from
+// the new set as deleted and touching last_seen on rows that are still
+// present.
+func (s *sqliteStore) Sync(accounts map[string]Account) error {
+	now := time.Now().UTC()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	seen := make(map[[3]string]bool, len(accounts))
+	for _, acc := range accounts {
+		key := [3]string{acc.ProjectID, acc.Email, acc.UniqueId}
+		seen[key] = true
+
+		_, err := tx.Exec(`
+			INSERT INTO accounts (project_id, email, unique_id, status, first_seen, last_seen)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT(project_id, email, unique_id) DO UPDATE SET
+				status = excluded.status,
+				last_seen = excluded.last_seen
+		`, acc.ProjectID, acc.Email, acc.UniqueId, acc.Status, now, now)
+		if err != nil {
+			return fmt.Errorf("upserting %s: %w", acc.Email, err)
+		}
+	}
+
+	rows, err := tx.Query(`SELECT project_id, email, unique_id FROM accounts WHERE status != 'deleted'`)
+	if err != nil {
+		return err
+	}
+	var stale [][3]string
+	for rows.Next() {
+		var k [3]string
+		if err := rows.Scan(&k[0], &k[1], &k[2]); err != nil {
+			rows.Close()
+			return err
+		}
+		if !seen[k] {
+			stale = append(stale, k)
+		}
+	}
+	rows.Close()
+
+	for _, k := range stale {
+		if _, err := tx.Exec(`UPDATE accounts SET status = 'deleted', last_seen = ? WHERE project_id = ? AND email = ? AND unique_id = ?`,
+			now, k[0], k[1], k[2]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteStore) All() (map[string]Account, error) {
+	rows, err := s.db.Query(`SELECT project_id, email, unique_id, status FROM accounts`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := make(map[string]Account)
+	for rows.Next() {
+		var acc Account
+		if err := rows.Scan(&acc.ProjectID, &acc.Email, &acc.UniqueId, &acc.Status); err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%s|%s|%s", acc.ProjectID, acc.Email, acc.UniqueId)
+		accounts[key] = acc
+	}
+	return accounts, rows.Err()
+}
+
+func (s *sqliteStore) SearchByProjectID(projectID string) ([]Account, error) {
+	rows, err := s.db.Query(`SELECT project_id, email, unique_id, status FROM accounts WHERE project_id = ?`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return scanAccounts(rows)
+}
+
+// SearchByEmail returns accounts whose email contains substr, matching
+// the CSV backend's case-insensitive strings.Contains semantics. This is
+// a LIKE scan rather than an accounts_fts MATCH query because FTS5 only
+// matches whole tokens (or token prefixes), not arbitrary substrings —
+// a MATCH query for "eploy" would miss "deploy-bot@...".
+func (s *sqliteStore) SearchByEmail(substr string) ([]Account, error) {
+	rows, err := s.db.Query(`
+		SELECT project_id, email, unique_id, status
+		FROM accounts
+		WHERE email LIKE ? ESCAPE '\' COLLATE NOCASE
+	`, likeSubstring(substr))
+	if err != nil {
+		return nil, err
+	}
+	return scanAccounts(rows)
+}
+
+func (s *sqliteStore) SearchBySubjectID(subjectID string) (Account, bool, error) {
+	row := s.db.QueryRow(`SELECT project_id, email, unique_id, status FROM accounts WHERE unique_id = ?`, subjectID)
+	var acc Account
+	if err := row.Scan(&acc.ProjectID, &acc.Email, &acc.UniqueId, &acc.Status); err != nil {
+		if err == sql.ErrNoRows {
+			return Account{}, false, nil
+		}
+		return Account{}, false, err
+	}
+	return acc, true, nil
+}
+
+// BulkLookup batches subject IDs into a single WHERE unique_id IN (...)
+// query instead of issuing one lookup per ID.
+func (s *sqliteStore) BulkLookup(ids []string) ([]Account, []string, error) {
+	if len(ids) == 0 {
+		return nil, nil, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`SELECT project_id, email, unique_id, status FROM accounts WHERE unique_id IN (%s)`, strings.Join(placeholders, ","))
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	found, err := scanAccounts(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolved := make(map[string]bool, len(found))
+	for _, acc := range found {
+		resolved[acc.UniqueId] = true
+	}
+	var missing []string
+	for _, id := range ids {
+		if !resolved[id] {
+			missing = append(missing, id)
+		}
+	}
+	return found, missing, nil
+}
+
+// RecordScan stores accounts as a JSON blob alongside the scan's
+// timestamp and duration, then deletes scans beyond the retain most
+// recent ones.
+func (s *sqliteStore) RecordScan(result ScanResult, retain int) error {
+	blob, err := json.Marshal(result.Accounts)
+	if err != nil {
+		return fmt.Errorf("encoding scan result: %w", err)
+	}
+
+	if _, err := s.db.Exec(`INSERT INTO scans (completed_at, duration_ms, result) VALUES (?, ?, ?)`,
+		result.CompletedAt, result.Duration.Milliseconds(), blob); err != nil {
+		return fmt.Errorf("recording scan: %w", err)
+	}
+
+	if retain > 0 {
+		if _, err := s.db.Exec(`
+			DELETE FROM scans WHERE id NOT IN (
+				SELECT id FROM scans ORDER BY id DESC LIMIT ?
+			)`, retain); err != nil {
+			return fmt.Errorf("pruning old scans: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *sqliteStore) ListScans() ([]ScanSummary, error) {
+	rows, err := s.db.Query(`SELECT id, completed_at, duration_ms, result FROM scans ORDER BY id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summaries []ScanSummary
+	for rows.Next() {
+		var (
+			id          int64
+			completedAt time.Time
+			durationMs  int64
+			blob        []byte
+		)
+		if err := rows.Scan(&id, &completedAt, &durationMs, &blob); err != nil {
+			return nil, err
+		}
+		var accounts map[string]Account
+		if err := json.Unmarshal(blob, &accounts); err != nil {
+			return nil, fmt.Errorf("decoding scan %d: %w", id, err)
+		}
+		summaries = append(summaries, ScanSummary{
+			ScanID:       id,
+			CompletedAt:  completedAt,
+			Duration:     time.Duration(durationMs) * time.Millisecond,
+			AccountCount: len(accounts),
+		})
+	}
+	return summaries, rows.Err()
+}
+
+func (s *sqliteStore) ScanAccounts(scanID int64) (map[string]Account, error) {
+	var blob []byte
+	row := s.db.QueryRow(`SELECT result FROM scans WHERE id = ?`, scanID)
+	if err := row.Scan(&blob); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no scan with id %d", scanID)
+		}
+		return nil, err
+	}
+
+	var accounts map[string]Account
+	if err := json.Unmarshal(blob, &accounts); err != nil {
+		return nil, fmt.Errorf("decoding scan %d: %w", scanID, err)
+	}
+	return accounts, nil
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
+
+func scanAccounts(rows *sql.Rows) ([]Account, error) {
+	defer rows.Close()
+	var accounts []Account
+	for rows.Next() {
+		var acc Account
+		if err := rows.Scan(&acc.ProjectID, &acc.Email, &acc.UniqueId, &acc.Status); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, acc)
+	}
+	return accounts, rows.Err()
+}
+
+// likeSubstring turns a raw substring into a %wrapped% LIKE pattern,
+// escaping the characters LIKE treats specially so the search behaves
+// like a literal substring match rather than a wildcard one.
+func likeSubstring(substr string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + escaper.Replace(substr) + "%"
+}
@@ -0,0 +1,119 @@
+// Package store persists discovered service accounts and exposes the
+// search operations used by analyze mode. Two backends are available:
+// NewCSVStore, which keeps the historical flat-file behavior, and
+// NewSQLiteStore, which indexes accounts in a local SQLite database so
+// lookups over large inventories don't require scanning every account
+// into memory.
+package store
+
+import "time"
+
+// Account is the store's view of a single GCP service account. It mirrors
+// main.ServiceAccount but is kept independent so this package has no
+// dependency on the CLI's types.
+type Account struct {
+	ProjectID string `json:"projectId"`
+	Email     string `json:"email"`
+	UniqueId  string `json:"uniqueId"`
+	Status    string `json:"status"`
+}
+
+// ScanResult is the snapshot recorded for a single load run.
+type ScanResult struct {
+	ScanID      int64
+	CompletedAt time.Time
+	Duration    time.Duration
+	Accounts    map[string]Account
+}
+
+// ScanSummary describes a recorded scan without its full account snapshot,
+// for the analyze-mode "history" listing.
+type ScanSummary struct {
+	ScanID       int64
+	CompletedAt  time.Time
+	Duration     time.Duration
+	AccountCount int
+}
+
+// AccountChange is a single account whose status differs between two
+// scans.
+type AccountChange struct {
+	Key    string
+	Before Account
+	After  Account
+}
+
+// AccountDiff is the result of comparing two scan snapshots.
+type AccountDiff struct {
+	Appeared    []Account
+	Disappeared []Account
+	Changed     []AccountChange
+}
+
+// DiffAccounts compares two scan snapshots keyed the same way All() keys
+// its results ("projectID|email|uniqueID") and reports what appeared,
+// disappeared, or changed status between them. It is shared by every
+// backend so diffing logic isn't duplicated per implementation.
+func DiffAccounts(from, to map[string]Account) AccountDiff {
+	var diff AccountDiff
+
+	for key, toAcc := range to {
+		fromAcc, existed := from[key]
+		if !existed {
+			diff.Appeared = append(diff.Appeared, toAcc)
+			continue
+		}
+		if fromAcc.Status != toAcc.Status {
+			diff.Changed = append(diff.Changed, AccountChange{Key: key, Before: fromAcc, After: toAcc})
+		}
+	}
+	for key, fromAcc := range from {
+		if _, stillPresent := to[key]; !stillPresent {
+			diff.Disappeared = append(diff.Disappeared, fromAcc)
+		}
+	}
+
+	return diff
+}
+
+// Store persists accounts discovered by a load run and serves the
+// lookups used by analyze mode.
+type Store interface {
+	// Sync merges accounts into the store, marking any previously active
+	// account that is absent from the new set as deleted.
+	Sync(accounts map[string]Account) error
+
+	// All returns every account currently in the store, keyed the same
+	// way callers have historically keyed them: "projectID|email|uniqueID".
+	All() (map[string]Account, error)
+
+	// SearchByProjectID returns accounts belonging to projectID.
+	SearchByProjectID(projectID string) ([]Account, error)
+
+	// SearchByEmail returns accounts whose email contains substr
+	// (case-insensitive).
+	SearchByEmail(substr string) ([]Account, error)
+
+	// SearchBySubjectID returns the account with the given unique ID, if
+	// any.
+	SearchBySubjectID(subjectID string) (Account, bool, error)
+
+	// BulkLookup resolves many subject IDs in a single query, returning
+	// the accounts that were found and the subset of ids that were not.
+	BulkLookup(ids []string) (found []Account, missing []string, err error)
+
+	// RecordScan persists a snapshot of a completed load run, then prunes
+	// older scans beyond the retain most recent ones. retain <= 0 means
+	// keep every scan.
+	RecordScan(result ScanResult, retain int) error
+
+	// ListScans returns recorded scans newest-first.
+	ListScans() ([]ScanSummary, error)
+
+	// ScanAccounts returns the account snapshot recorded for scanID.
+	ScanAccounts(scanID int64) (map[string]Account, error)
+
+	// Close releases any resources (open file handles, DB connections)
+	// held by the store.
+	Close() error
+}
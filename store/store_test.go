@@ -0,0 +1,200 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// backend pairs a Store constructor with a name, so shared behavior tests
+// run against both backends with one t.Run per backend.
+type backend struct {
+	name string
+	new  func(t *testing.T) Store
+}
+
+var backends = []backend{
+	{name: "csv", new: func(t *testing.T) Store {
+		return NewCSVStore(filepath.Join(t.TempDir(), "accounts.csv"))
+	}},
+	{name: "sqlite", new: func(t *testing.T) Store {
+		st, err := NewSQLiteStore(filepath.Join(t.TempDir(), "accounts.db"))
+		if err != nil {
+			t.Fatalf("NewSQLiteStore: %v", err)
+		}
+		return st
+	}},
+}
+
+// eachBackend runs test against a fresh instance of every backend.
+func eachBackend(t *testing.T, test func(t *testing.T, st Store)) {
+	t.Helper()
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			st := b.new(t)
+			defer st.Close()
+			test(t, st)
+		})
+	}
+}
+
+// TestSyncRoundTrip verifies Sync persists accounts and marks a
+// previously-synced account absent from a later Sync as deleted rather
+// than dropping it, for both backends.
+func TestSyncRoundTrip(t *testing.T) {
+	eachBackend(t, func(t *testing.T, st Store) {
+		first := map[string]Account{
+			"p|a@x.com|1": {ProjectID: "p", Email: "a@x.com", UniqueId: "1", Status: "active"},
+			"p|b@x.com|2": {ProjectID: "p", Email: "b@x.com", UniqueId: "2", Status: "active"},
+		}
+		if err := st.Sync(first); err != nil {
+			t.Fatalf("Sync: %v", err)
+		}
+
+		all, err := st.All()
+		if err != nil {
+			t.Fatalf("All: %v", err)
+		}
+		if len(all) != 2 {
+			t.Fatalf("All() = %d accounts, want 2", len(all))
+		}
+
+		second := map[string]Account{
+			"p|a@x.com|1": {ProjectID: "p", Email: "a@x.com", UniqueId: "1", Status: "active"},
+		}
+		if err := st.Sync(second); err != nil {
+			t.Fatalf("Sync: %v", err)
+		}
+
+		all, err = st.All()
+		if err != nil {
+			t.Fatalf("All: %v", err)
+		}
+		if got := all["p|a@x.com|1"].Status; got != "active" {
+			t.Errorf("account a Status = %q, want active", got)
+		}
+		if got := all["p|b@x.com|2"].Status; got != "deleted" {
+			t.Errorf("account b Status = %q, want deleted", got)
+		}
+	})
+}
+
+// TestRecordScanRetention verifies RecordScan keeps only the retain most
+// recent snapshots, for both backends.
+func TestRecordScanRetention(t *testing.T) {
+	eachBackend(t, func(t *testing.T, st Store) {
+		for i := 0; i < 5; i++ {
+			result := ScanResult{
+				CompletedAt: time.Unix(int64(i), 0).UTC(),
+				Duration:    time.Second,
+				Accounts: map[string]Account{
+					"p|a@x.com|1": {ProjectID: "p", Email: "a@x.com", UniqueId: "1", Status: "active"},
+				},
+			}
+			if err := st.RecordScan(result, 3); err != nil {
+				t.Fatalf("RecordScan: %v", err)
+			}
+		}
+
+		summaries, err := st.ListScans()
+		if err != nil {
+			t.Fatalf("ListScans: %v", err)
+		}
+		if len(summaries) != 3 {
+			t.Fatalf("ListScans() = %d scans, want 3 after retention pruning", len(summaries))
+		}
+	})
+}
+
+// TestSearchByEmailSubstringParity pins SearchByEmail's substring
+// semantics across both backends. The sqlite backend used to run an
+// FTS5 MATCH query, which only matches whole tokens or token prefixes,
+// so a fragment from the middle of a word (e.g. "eploy" from
+// "deploy-bot") matched on the CSV backend but not sqlite.
+func TestSearchByEmailSubstringParity(t *testing.T) {
+	accounts := map[string]Account{
+		"p|deploy-bot@proj-a.iam.gserviceaccount.com|1": {
+			ProjectID: "p", Email: "deploy-bot@proj-a.iam.gserviceaccount.com", UniqueId: "1", Status: "active",
+		},
+	}
+
+	eachBackend(t, func(t *testing.T, st Store) {
+		if err := st.Sync(accounts); err != nil {
+			t.Fatalf("Sync: %v", err)
+		}
+
+		for _, substr := range []string{"eploy", "DEPLOY-BOT", "proj-a"} {
+			matches, err := st.SearchByEmail(substr)
+			if err != nil {
+				t.Fatalf("SearchByEmail(%q): %v", substr, err)
+			}
+			if len(matches) != 1 {
+				t.Errorf("SearchByEmail(%q) = %d matches, want 1", substr, len(matches))
+			}
+		}
+
+		if matches, err := st.SearchByEmail("nonexistent"); err != nil {
+			t.Fatalf("SearchByEmail: %v", err)
+		} else if len(matches) != 0 {
+			t.Errorf("SearchByEmail(%q) = %d matches, want 0", "nonexistent", len(matches))
+		}
+	})
+}
+
+// TestSQLiteMigrationReopen verifies a database is migrated once, and
+// reopening it afterward neither errors nor loses data.
+func TestSQLiteMigrationReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.db")
+
+	st, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore: %v", err)
+	}
+	if err := st.Sync(map[string]Account{
+		"p|a@x.com|1": {ProjectID: "p", Email: "a@x.com", UniqueId: "1", Status: "active"},
+	}); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("reopening migrated database: %v", err)
+	}
+	defer reopened.Close()
+
+	all, err := reopened.All()
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if len(all) != 1 {
+		t.Fatalf("All() after reopen = %d accounts, want 1", len(all))
+	}
+}
+
+// TestDiffAccounts covers the appeared/disappeared/changed classification
+// that analyze-mode's diff view relies on.
+func TestDiffAccounts(t *testing.T) {
+	from := map[string]Account{
+		"p|a@x.com|1": {ProjectID: "p", Email: "a@x.com", UniqueId: "1", Status: "active"},
+		"p|b@x.com|2": {ProjectID: "p", Email: "b@x.com", UniqueId: "2", Status: "active"},
+	}
+	to := map[string]Account{
+		"p|a@x.com|1": {ProjectID: "p", Email: "a@x.com", UniqueId: "1", Status: "deleted"},
+		"p|c@x.com|3": {ProjectID: "p", Email: "c@x.com", UniqueId: "3", Status: "active"},
+	}
+
+	diff := DiffAccounts(from, to)
+
+	if len(diff.Appeared) != 1 || diff.Appeared[0].UniqueId != "3" {
+		t.Errorf("Appeared = %+v, want [c]", diff.Appeared)
+	}
+	if len(diff.Disappeared) != 1 || diff.Disappeared[0].UniqueId != "2" {
+		t.Errorf("Disappeared = %+v, want [b]", diff.Disappeared)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Key != "p|a@x.com|1" {
+		t.Errorf("Changed = %+v, want [p|a@x.com|1: active->deleted]", diff.Changed)
+	}
+}